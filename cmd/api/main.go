@@ -7,13 +7,20 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/saisudhir14/fleet-event-stream/internal/handlers"
+	"github.com/saisudhir14/fleet-event-stream/internal/httpmetrics"
+	"github.com/saisudhir14/fleet-event-stream/internal/ingest"
 	"github.com/saisudhir14/fleet-event-stream/internal/metrics"
+	"github.com/saisudhir14/fleet-event-stream/internal/metricsauth"
 	"github.com/saisudhir14/fleet-event-stream/internal/processor"
+	"github.com/saisudhir14/fleet-event-stream/internal/stream"
 )
 
 // Constants for default configuration values
@@ -28,14 +35,27 @@ func main() {
 		Level: slog.LevelInfo,
 	}))
 	// Initialize components
-	m := metrics.NewMetrics()
-	proc := processor.NewEventProcessor(logger)
+	reg := prometheus.NewRegistry()
+	metricsCfg := metrics.MetricsConfig{
+		PerVehicleLabels: getEnv("METRICS_PER_VEHICLE_LABELS", "false") == "true",
+	}
+	m := metrics.NewMetrics(reg, metricsCfg)
+	poolCfg := processor.PoolConfig{
+		WorkerCount: getEnvInt("WORKER_COUNT", 0),
+		QueueDepth:  getEnvInt("QUEUE_DEPTH", 0),
+	}
+	proc := processor.NewEventProcessor(logger, m, poolCfg)
+	hub := stream.NewHub(logger, m, 0)
+	proc.SetPublisher(hub)
 	h := handlers.NewHandler(proc, m, logger)
+	instrument := httpmetrics.Middleware(m)
 	mux := http.NewServeMux()
-	mux.HandleFunc("/health", h.HealthCheck)
-	mux.HandleFunc("/ready", h.ReadyCheck)
-	mux.HandleFunc("/api/v1/events", h.IngestEvent)
-	mux.HandleFunc("/api/v1/stats", h.GetStats)
+	mux.Handle("/health", instrument(http.HandlerFunc(h.HealthCheck)))
+	mux.Handle("/ready", instrument(http.HandlerFunc(h.ReadyCheck)))
+	mux.Handle("/api/v1/events", instrument(http.HandlerFunc(h.IngestEvent)))
+	mux.Handle("/api/v1/events:batch", instrument(http.HandlerFunc(h.IngestEventBatch)))
+	mux.Handle("/api/v1/stats", instrument(http.HandlerFunc(h.GetStats)))
+	mux.Handle("/api/v1/subscribe", instrument(http.HandlerFunc(hub.ServeWS)))
 	port := getEnv("PORT", defaultPort)
 
 	server := &http.Server{
@@ -46,14 +66,39 @@ func main() {
 		IdleTimeout:  60 * time.Second,
 	}
 	// Metrics server
+	metricsAuthCfg := metricsauth.Config{
+		BasicUser:   getEnv("METRICS_AUTH_USER", ""),
+		BasicPass:   getEnv("METRICS_AUTH_PASS", ""),
+		BearerToken: getEnv("METRICS_BEARER_TOKEN", ""),
+	}
 	metricsMux := http.NewServeMux()
-	metricsMux.Handle("/metrics", promhttp.Handler())
+	metricsMux.Handle("/metrics", metricsauth.Middleware(metricsAuthCfg, m)(promhttp.HandlerFor(reg, promhttp.HandlerOpts{})))
 	metricsPort := getEnv("METRICS_PORT", defaultMetricsPort)
 	metricsServer := &http.Server{
 
 		Addr:    fmt.Sprintf(":%s", metricsPort),
 		Handler: metricsMux,
 	}
+	// The primary API server (health/ready/stats/subscribe, plus HTTP event
+	// ingestion) always runs: it's not merely one ingestion option among
+	// others, so unlike Kafka it can't be opted out of via INGEST_SOURCES.
+	// INGEST_SOURCES is a comma-separated list of additional, opt-in
+	// sources layered on top; currently only "kafka" (brokers from
+	// KAFKA_BROKERS, default localhost:9092) is recognized.
+	httpSource := ingest.NewHTTPSource(server, logger)
+	sources := append([]ingest.EventSource{httpSource}, selectIngestSources(getEnv("INGEST_SOURCES", ""), proc, m, logger)...)
+
+	runCtx, cancelRun := context.WithCancel(context.Background())
+	proc.Start(runCtx)
+	for _, source := range sources {
+		src := source
+		go func() {
+			if err := src.Start(runCtx); err != nil {
+				logger.Error("ingest source failed", "source", src.Name(), "error", err)
+			}
+		}()
+	}
+
 	// Start servers in separate goroutines
 	go func() {
 		logger.Info("starting metrics server", "port", metricsPort)
@@ -61,17 +106,12 @@ func main() {
 			logger.Error("metrics server failed", "error", err)
 		}
 	}()
-	// Start servers in separate goroutines
-	go func() {
-		logger.Info("starting API server", "port", port)
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			logger.Error("API server failed", "error", err)
-		}
-	}()
+
 	// Graceful shutdown on interrupt signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
+	cancelRun()
 
 	logger.Info("shutting down servers...")
 
@@ -79,18 +119,48 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
 
-	// Shutdown servers
-	if err := server.Shutdown(ctx); err != nil {
-		logger.Error("API server shutdown failed", "error", err)
+	for _, source := range sources {
+		if err := source.Stop(ctx); err != nil {
+			logger.Error("ingest source shutdown failed", "source", source.Name(), "error", err)
+		}
 	}
 
 	if err := metricsServer.Shutdown(ctx); err != nil {
 		logger.Error("metrics server shutdown failed", "error", err)
 	}
+
+	proc.Stop()
+	hub.Shutdown(ctx)
 	logger.Info("servers stopped gracefully")
 
 }
 
+// selectIngestSources builds the set of additional, opt-in ingest.EventSource
+// instances named in the comma-separated sourcesEnv string (e.g. "kafka").
+// The primary HTTP server is started unconditionally by main and is not
+// selected here. Unknown names are logged and skipped.
+func selectIngestSources(sourcesEnv string, proc *processor.EventProcessor, m *metrics.Metrics, logger *slog.Logger) []ingest.EventSource {
+	var sources []ingest.EventSource
+	for _, name := range strings.Split(sourcesEnv, ",") {
+		switch strings.TrimSpace(name) {
+		case "kafka":
+			sources = append(sources, ingest.NewKafkaSource(ingest.KafkaConfig{
+				Brokers:  strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ","),
+				Topic:    getEnv("KAFKA_TOPIC", "fleet-events"),
+				GroupID:  getEnv("KAFKA_GROUP_ID", "fleet-event-stream"),
+				DLQTopic: getEnv("KAFKA_DLQ_TOPIC", ""),
+			}, proc, m, logger))
+		case "", "http":
+			// "" ignores stray separators, e.g. a trailing comma; "http" is
+			// accepted for backwards compatibility but is a no-op, since the
+			// primary server is already started unconditionally.
+		default:
+			logger.Warn("unknown ingest source, ignoring", "source", name)
+		}
+	}
+	return sources
+}
+
 // getEnv retrieves the value of the environment variable named by the key.
 // If the variable is empty or not present, it returns the defaultValue.
 func getEnv(key, defaultValue string) string {
@@ -99,3 +169,18 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvInt retrieves the integer value of the environment variable named
+// by key. If the variable is empty, not present, or not a valid integer,
+// it returns defaultValue.
+func getEnvInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
+}
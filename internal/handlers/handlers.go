@@ -1,9 +1,15 @@
 package handlers
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/saisudhir14/fleet-event-stream/internal/metrics"
@@ -67,8 +73,13 @@ func (h *Handler) ReadyCheck(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(ready)
 }
 
+// retryAfterSeconds is advertised on 429 responses when the worker pool
+// queue is full, giving clients a hint for how long to back off.
+const retryAfterSeconds = "1"
+
 // IngestEvent handles the /api/v1/events endpoint
-// Accepts vehicle event data and processes it
+// Accepts vehicle event data and hands it to the worker pool for
+// asynchronous processing.
 // parameters: w http.ResponseWriter: The HTTP response writer.
 //
 //	r *http.Request: The HTTP request containing event data.
@@ -88,22 +99,20 @@ func (h *Handler) IngestEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	start := time.Now()
-
-	if err := h.processor.ProcessEvent(r.Context(), &event); err != nil {
-		h.metrics.RecordValidation(false)
+	if err := h.processor.Enqueue(&event); err != nil {
+		if errors.Is(err, processor.ErrQueueFull) {
+			h.logger.Warn("processing queue full, rejecting event", "event_id", event.EventID)
+			w.Header().Set("Retry-After", retryAfterSeconds)
+			http.Error(w, "processing queue is full", http.StatusTooManyRequests)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	// Record metrics
-	duration := time.Since(start).Seconds()
-	h.metrics.RecordValidation(true)
-	h.metrics.RecordEventProcessed(event.EventType, event.VehicleID)
-	h.metrics.RecordProcessingDuration(event.EventType, duration)
-	h.logger.Info("event ingested",
+
+	h.logger.Info("event accepted",
 		"event_id", event.EventID,
 		"vehicle_id", event.VehicleID,
-		"duration_ms", duration*1000,
 	)
 
 	response := map[string]string{
@@ -117,18 +126,169 @@ func (h *Handler) IngestEvent(w http.ResponseWriter, r *http.Request) {
 
 }
 
+// batchItemResult reports the outcome of a single event within a batch
+// ingest request.
+type batchItemResult struct {
+	Index   int    `json:"index"`
+	EventID string `json:"event_id,omitempty"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+const (
+	// maxBatchBodyBytes bounds how much of the request body decodeBatch will
+	// read, regardless of framing (JSON array or NDJSON).
+	maxBatchBodyBytes = 10 << 20 // 10 MiB
+	// maxBatchSize bounds how many events a single batch request may
+	// contain. Past this, the request is rejected outright rather than
+	// fanning out one goroutine per item with no cap.
+	maxBatchSize = 5000
+)
+
+// IngestEventBatch handles the POST /api/v1/events:batch endpoint. It
+// accepts either a JSON array of events, or NDJSON (one event per line)
+// when Content-Type is application/x-ndjson, validates and enqueues every
+// entry in parallel (one handler goroutine per entry; the worker pool only
+// processes the entries that were accepted), and reports a per-item result
+// so a partially-invalid batch doesn't fail as a whole. The request body is
+// capped at maxBatchBodyBytes and the batch at maxBatchSize events, so a
+// single request can't force unbounded allocation or goroutine fan-out.
+// parameters: w http.ResponseWriter: The HTTP response writer.
+//
+//	r *http.Request: The HTTP request containing the batch of events.
+//
+// returns: none but writes a JSON response with one result per input event.
+func (h *Handler) IngestEventBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	events, err := decodeBatch(w, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(events) > maxBatchSize {
+		http.Error(w, fmt.Sprintf("batch exceeds maximum size of %d events", maxBatchSize), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	results := make([]batchItemResult, len(events))
+	var wg sync.WaitGroup
+	for i := range events {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = h.enqueueBatchItem(i, &events[i])
+		}(i)
+	}
+	wg.Wait()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusMultiStatus)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"results": results,
+	})
+}
+
+// enqueueBatchItem validates and enqueues a single batch entry, translating
+// the outcome into a batchItemResult.
+func (h *Handler) enqueueBatchItem(index int, event *models.VehicleEvent) batchItemResult {
+	if err := h.processor.Enqueue(event); err != nil {
+		status := "error"
+		if errors.Is(err, processor.ErrQueueFull) {
+			status = "rejected"
+		}
+		return batchItemResult{Index: index, EventID: event.EventID, Status: status, Error: err.Error()}
+	}
+	return batchItemResult{Index: index, EventID: event.EventID, Status: "accepted"}
+}
+
+// decodeBatch parses the request body as either an NDJSON stream
+// (Content-Type: application/x-ndjson) or a JSON array of events. The body
+// is capped at maxBatchBodyBytes; a larger body fails with an error rather
+// than being read into memory in full.
+func decodeBatch(w http.ResponseWriter, r *http.Request) ([]models.VehicleEvent, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBatchBodyBytes)
+
+	if r.Header.Get("Content-Type") == "application/x-ndjson" {
+		var events []models.VehicleEvent
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+			var event models.VehicleEvent
+			if err := json.Unmarshal(line, &event); err != nil {
+				return nil, fmt.Errorf("invalid ndjson line: %w", err)
+			}
+			events = append(events, event)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read ndjson body: %w", err)
+		}
+		return events, nil
+	}
+
+	var events []models.VehicleEvent
+	if err := json.NewDecoder(r.Body).Decode(&events); err != nil {
+		return nil, fmt.Errorf("invalid request body: %w", err)
+	}
+	return events, nil
+}
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 200
+)
+
 // GetStats handles the /api/v1/stats endpoint
-// Returns processing statistics
+// Returns processing statistics. Per-vehicle counts are served from this
+// endpoint rather than Prometheus labels, since the number of vehicles can
+// grow far past what's safe to put on a metric label.
+//
+// Query parameters:
+//
+//	top_n: when set, returns only the top_n vehicles by event count.
+//	page, page_size: when top_n is absent, paginate the full vehicle list
+//	  (page is 1-indexed, page_size defaults to 20 and is capped at 200).
+//
 // parameters: w http.ResponseWriter: The HTTP response writer.
 //
 //	r *http.Request: The HTTP request.
 //
 // returns: none but writes a JSON response containing statistics.
 func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
 	stats := map[string]interface{}{
 		"total_events_processed": h.processor.GetTotalEventCount(),
 		"timestamp":              time.Now().UTC().Format(time.RFC3339),
 	}
+
+	if topN, err := strconv.Atoi(query.Get("top_n")); err == nil && topN > 0 {
+		stats["top_vehicles"] = h.processor.TopVehicles(topN)
+	} else if query.Has("page") || query.Has("page_size") {
+		page, err := strconv.Atoi(query.Get("page"))
+		if err != nil || page < 1 {
+			page = 1
+		}
+		pageSize, err := strconv.Atoi(query.Get("page_size"))
+		if err != nil || pageSize < 1 {
+			pageSize = defaultPageSize
+		}
+		if pageSize > maxPageSize {
+			pageSize = maxPageSize
+		}
+
+		vehicles, total := h.processor.VehiclesPage(page, pageSize)
+		stats["vehicles"] = vehicles
+		stats["page"] = page
+		stats["page_size"] = pageSize
+		stats["total_vehicles"] = total
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(stats)
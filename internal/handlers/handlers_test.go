@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/saisudhir14/fleet-event-stream/internal/metrics"
+	"github.com/saisudhir14/fleet-event-stream/internal/models"
+	"github.com/saisudhir14/fleet-event-stream/internal/processor"
+)
+
+func newTestHandler(t *testing.T, poolCfg processor.PoolConfig) *Handler {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	m := metrics.NewMetrics(prometheus.NewRegistry(), metrics.MetricsConfig{})
+	proc := processor.NewEventProcessor(logger, m, poolCfg)
+	return NewHandler(proc, m, logger)
+}
+
+func validEvent(eventID string) models.VehicleEvent {
+	return models.VehicleEvent{
+		EventID:   eventID,
+		VehicleID: "vehicle-1",
+		EventType: models.EventTypePosition,
+		Timestamp: time.Now(),
+	}
+}
+
+// TestHandler_IngestEvent_QueueFull exercises the backpressure path: with no
+// worker draining the queue, a queue of depth 1 accepts exactly one event
+// and rejects the next with 429 and a Retry-After header.
+func TestHandler_IngestEvent_QueueFull(t *testing.T) {
+	h := newTestHandler(t, processor.PoolConfig{WorkerCount: 1, QueueDepth: 1})
+
+	post := func(event models.VehicleEvent) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(event)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/events", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+		h.IngestEvent(rec, req)
+		return rec
+	}
+
+	first := post(validEvent("evt-1"))
+	if first.Code != http.StatusAccepted {
+		t.Fatalf("first enqueue: got status %d, want %d", first.Code, http.StatusAccepted)
+	}
+
+	second := post(validEvent("evt-2"))
+	if second.Code != http.StatusTooManyRequests {
+		t.Fatalf("second enqueue: got status %d, want %d", second.Code, http.StatusTooManyRequests)
+	}
+	if second.Header().Get("Retry-After") == "" {
+		t.Error("expected Retry-After header on 429")
+	}
+}
+
+func TestHandler_IngestEvent_InvalidBody(t *testing.T) {
+	h := newTestHandler(t, processor.PoolConfig{})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/events", strings.NewReader("not json"))
+	rec := httptest.NewRecorder()
+	h.IngestEvent(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("got status %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+// TestHandler_IngestEventBatch_PartialFailure confirms one invalid event in
+// a batch doesn't fail the whole request: the response is 207 with a
+// per-item result.
+func TestHandler_IngestEventBatch_PartialFailure(t *testing.T) {
+	h := newTestHandler(t, processor.PoolConfig{})
+
+	invalid := validEvent("")
+	batch := []models.VehicleEvent{validEvent("evt-1"), invalid}
+	body, _ := json.Marshal(batch)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/events:batch", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.IngestEventBatch(rec, req)
+
+	if rec.Code != http.StatusMultiStatus {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusMultiStatus)
+	}
+
+	var resp struct {
+		Results []batchItemResult `json:"results"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Results) != 2 {
+		t.Fatalf("got %d results, want 2", len(resp.Results))
+	}
+	if resp.Results[0].Status != "accepted" {
+		t.Errorf("item 0: got status %q, want %q", resp.Results[0].Status, "accepted")
+	}
+	if resp.Results[1].Status != "error" || resp.Results[1].Error == "" {
+		t.Errorf("item 1: got %+v, want a validation error", resp.Results[1])
+	}
+}
+
+// TestHandler_IngestEventBatch_NDJSON confirms the NDJSON framing is parsed
+// line by line and every valid line is enqueued.
+func TestHandler_IngestEventBatch_NDJSON(t *testing.T) {
+	h := newTestHandler(t, processor.PoolConfig{})
+
+	line1, _ := json.Marshal(validEvent("evt-1"))
+	line2, _ := json.Marshal(validEvent("evt-2"))
+	body := strings.Join([]string{string(line1), string(line2)}, "\n")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/events:batch", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	rec := httptest.NewRecorder()
+	h.IngestEventBatch(rec, req)
+
+	if rec.Code != http.StatusMultiStatus {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusMultiStatus)
+	}
+
+	var resp struct {
+		Results []batchItemResult `json:"results"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Results) != 2 {
+		t.Fatalf("got %d results, want 2", len(resp.Results))
+	}
+	for _, r := range resp.Results {
+		if r.Status != "accepted" {
+			t.Errorf("got status %q, want %q", r.Status, "accepted")
+		}
+	}
+}
+
+// TestHandler_IngestEventBatch_OversizedRejected confirms a batch over
+// maxBatchSize is rejected outright rather than fanning out one goroutine
+// per item.
+func TestHandler_IngestEventBatch_OversizedRejected(t *testing.T) {
+	h := newTestHandler(t, processor.PoolConfig{})
+
+	batch := make([]models.VehicleEvent, maxBatchSize+1)
+	for i := range batch {
+		batch[i] = validEvent("evt")
+	}
+	body, _ := json.Marshal(batch)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/events:batch", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.IngestEventBatch(rec, req)
+
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("got status %d, want %d", rec.Code, http.StatusRequestEntityTooLarge)
+	}
+}
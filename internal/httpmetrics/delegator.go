@@ -0,0 +1,151 @@
+package httpmetrics
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+)
+
+// delegator wraps an http.ResponseWriter and records the status code and
+// number of bytes written so middleware can observe them after the handler
+// returns.
+type delegator interface {
+	http.ResponseWriter
+
+	// Status returns the HTTP status code that was written, defaulting to
+	// http.StatusOK if WriteHeader was never called.
+	Status() int
+	// Written returns the number of bytes written to the response body.
+	Written() int64
+}
+
+// responseWriterDelegator is the base delegator embedded by every
+// interface-specific wrapper below.
+type responseWriterDelegator struct {
+	http.ResponseWriter
+
+	status      int
+	written     int64
+	wroteHeader bool
+}
+
+func (d *responseWriterDelegator) Status() int {
+	if !d.wroteHeader {
+		return http.StatusOK
+	}
+	return d.status
+}
+
+func (d *responseWriterDelegator) Written() int64 {
+	return d.written
+}
+
+func (d *responseWriterDelegator) WriteHeader(code int) {
+	d.status = code
+	d.wroteHeader = true
+	d.ResponseWriter.WriteHeader(code)
+}
+
+func (d *responseWriterDelegator) Write(b []byte) (int, error) {
+	if !d.wroteHeader {
+		d.WriteHeader(http.StatusOK)
+	}
+	n, err := d.ResponseWriter.Write(b)
+	d.written += int64(n)
+	return n, err
+}
+
+// The following wrappers each layer one or more of the optional
+// http.ResponseWriter interfaces (http.Flusher, http.Hijacker,
+// http.CloseNotifier) on top of responseWriterDelegator, so that wrapping a
+// handler's ResponseWriter never silently drops support for streaming or
+// connection hijacking.
+
+type flusherDelegator struct{ *responseWriterDelegator }
+
+func (d flusherDelegator) Flush() {
+	d.ResponseWriter.(http.Flusher).Flush()
+}
+
+type hijackerDelegator struct{ *responseWriterDelegator }
+
+func (d hijackerDelegator) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return d.ResponseWriter.(http.Hijacker).Hijack()
+}
+
+//lint:ignore SA1019 http.CloseNotifier is deprecated but some callers still rely on it.
+type closeNotifierDelegator struct{ *responseWriterDelegator }
+
+func (d closeNotifierDelegator) CloseNotify() <-chan bool {
+	return d.ResponseWriter.(http.CloseNotifier).CloseNotify()
+}
+
+type flusherHijackerDelegator struct {
+	*responseWriterDelegator
+	flusherDelegator
+	hijackerDelegator
+}
+
+type flusherCloseNotifierDelegator struct {
+	*responseWriterDelegator
+	flusherDelegator
+	closeNotifierDelegator
+}
+
+type hijackerCloseNotifierDelegator struct {
+	*responseWriterDelegator
+	hijackerDelegator
+	closeNotifierDelegator
+}
+
+type flusherHijackerCloseNotifierDelegator struct {
+	*responseWriterDelegator
+	flusherDelegator
+	hijackerDelegator
+	closeNotifierDelegator
+}
+
+// pickDelegator selects the concrete delegator implementation for w based on
+// a bitmask of which optional interfaces it satisfies, so status code and
+// byte count can be captured without breaking http.Flusher, http.Hijacker,
+// or http.CloseNotifier support.
+func pickDelegator(base *responseWriterDelegator, w http.ResponseWriter) delegator {
+	const (
+		isFlusher      = 1 << 0
+		isHijacker     = 1 << 1
+		isCloseNotifer = 1 << 2
+	)
+
+	var id int
+	if _, ok := w.(http.Flusher); ok {
+		id |= isFlusher
+	}
+	if _, ok := w.(http.Hijacker); ok {
+		id |= isHijacker
+	}
+	if _, ok := w.(http.CloseNotifier); ok { //nolint:staticcheck
+		id |= isCloseNotifer
+	}
+
+	switch id {
+	case isFlusher:
+		return flusherDelegator{base}
+	case isHijacker:
+		return hijackerDelegator{base}
+	case isCloseNotifer:
+		return closeNotifierDelegator{base}
+	case isFlusher | isHijacker:
+		return flusherHijackerDelegator{base, flusherDelegator{base}, hijackerDelegator{base}}
+	case isFlusher | isCloseNotifer:
+		return flusherCloseNotifierDelegator{base, flusherDelegator{base}, closeNotifierDelegator{base}}
+	case isHijacker | isCloseNotifer:
+		return hijackerCloseNotifierDelegator{base, hijackerDelegator{base}, closeNotifierDelegator{base}}
+	case isFlusher | isHijacker | isCloseNotifer:
+		return flusherHijackerCloseNotifierDelegator{base, flusherDelegator{base}, hijackerDelegator{base}, closeNotifierDelegator{base}}
+	default:
+		return base
+	}
+}
+
+var _ io.Writer = (*responseWriterDelegator)(nil)
@@ -0,0 +1,38 @@
+// Package httpmetrics provides an HTTP middleware that records
+// Prometheus request metrics (count, in-flight, duration, response size)
+// for every handler it wraps.
+package httpmetrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/saisudhir14/fleet-event-stream/internal/metrics"
+)
+
+// Middleware wraps next so that every request passing through it is
+// recorded on m: request count, in-flight gauge, duration histogram, and
+// response size histogram, all partitioned by method, path, and status
+// code. The underlying http.ResponseWriter is wrapped with the delegator
+// that matches the optional interfaces it implements, so streaming
+// handlers that rely on http.Flusher, http.Hijacker, or http.CloseNotifier
+// keep working.
+func Middleware(m *metrics.Metrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			m.HTTPRequestsInFlight.Inc()
+			defer m.HTTPRequestsInFlight.Dec()
+
+			base := &responseWriterDelegator{ResponseWriter: w}
+			d := pickDelegator(base, w)
+
+			start := time.Now()
+			next.ServeHTTP(d, r)
+			duration := time.Since(start).Seconds()
+
+			status := strconv.Itoa(d.Status())
+			m.RecordHTTPRequest(r.Method, r.URL.Path, status, duration, float64(d.Written()))
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package ingest
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+)
+
+// HTTPSource adapts an *http.Server to the EventSource interface so it can
+// be selected and supervised the same way as other ingest sources.
+type HTTPSource struct {
+	server *http.Server
+	logger *slog.Logger
+}
+
+// NewHTTPSource wraps server as an EventSource. The caller is still
+// responsible for registering handlers on server.Handler before Start is
+// called.
+// parameters: server *http.Server: The HTTP server to run.
+//
+//	logger *slog.Logger: The logger instance.
+//
+// returns: *HTTPSource: A new HTTPSource instance.
+func NewHTTPSource(server *http.Server, logger *slog.Logger) *HTTPSource {
+	return &HTTPSource{server: server, logger: logger}
+}
+
+// Name returns "http".
+func (s *HTTPSource) Name() string {
+	return "http"
+}
+
+// Start runs the HTTP server until it is shut down via Stop, ignoring the
+// expected http.ErrServerClosed error.
+func (s *HTTPSource) Start(ctx context.Context) error {
+	s.logger.Info("starting ingest source", "source", s.Name(), "addr", s.server.Addr)
+	if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// Stop gracefully shuts the HTTP server down.
+func (s *HTTPSource) Stop(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
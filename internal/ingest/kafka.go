@@ -0,0 +1,174 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	kafka "github.com/segmentio/kafka-go"
+
+	"github.com/saisudhir14/fleet-event-stream/internal/metrics"
+	"github.com/saisudhir14/fleet-event-stream/internal/models"
+	"github.com/saisudhir14/fleet-event-stream/internal/processor"
+)
+
+// maxProcessRetries bounds how many times a single message is retried
+// before it is written to the dead-letter topic.
+const maxProcessRetries = 3
+
+// KafkaConfig configures a KafkaSource.
+type KafkaConfig struct {
+	Brokers  []string
+	Topic    string
+	GroupID  string
+	DLQTopic string
+}
+
+// KafkaSource consumes VehicleEvent JSON records from a Kafka topic and
+// pushes them through the same EventProcessor.ProcessEvent pipeline as the
+// HTTP source. It commits offsets only after successful processing
+// (at-least-once delivery) and routes messages that fail validation
+// repeatedly to a dead-letter topic instead of blocking the partition.
+type KafkaSource struct {
+	cfg       KafkaConfig
+	reader    *kafka.Reader
+	dlqWriter *kafka.Writer
+	processor *processor.EventProcessor
+	metrics   *metrics.Metrics
+	logger    *slog.Logger
+}
+
+// NewKafkaSource creates a new KafkaSource.
+// parameters: cfg KafkaConfig: Broker/topic/group/DLQ configuration.
+//
+//	proc *processor.EventProcessor: The event processor to push decoded events through.
+//	m *metrics.Metrics: The metrics collector instance.
+//	logger *slog.Logger: The logger instance.
+//
+// returns: *KafkaSource: A new KafkaSource instance.
+func NewKafkaSource(cfg KafkaConfig, proc *processor.EventProcessor, m *metrics.Metrics, logger *slog.Logger) *KafkaSource {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: cfg.Brokers,
+		Topic:   cfg.Topic,
+		GroupID: cfg.GroupID,
+	})
+
+	var dlqWriter *kafka.Writer
+	if cfg.DLQTopic != "" {
+		dlqWriter = &kafka.Writer{
+			Addr:     kafka.TCP(cfg.Brokers...),
+			Topic:    cfg.DLQTopic,
+			Balancer: &kafka.LeastBytes{},
+		}
+	}
+
+	return &KafkaSource{
+		cfg:       cfg,
+		reader:    reader,
+		dlqWriter: dlqWriter,
+		processor: proc,
+		metrics:   m,
+		logger:    logger,
+	}
+}
+
+// Name returns "kafka".
+func (s *KafkaSource) Name() string {
+	return "kafka"
+}
+
+// Start consumes messages until ctx is cancelled. Each message is decoded,
+// run through EventProcessor.ProcessEvent, retried up to maxProcessRetries
+// times on failure, and the offset is committed only once the message has
+// succeeded, been written to the dead-letter topic, or been dropped because
+// no dead-letter topic is configured.
+func (s *KafkaSource) Start(ctx context.Context) error {
+	s.logger.Info("starting ingest source", "source", s.Name(), "topic", s.cfg.Topic, "group_id", s.cfg.GroupID)
+
+	go s.reportLag(ctx)
+
+	for {
+		msg, err := s.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		s.process(ctx, msg)
+
+		if err := s.reader.CommitMessages(ctx, msg); err != nil {
+			s.logger.Error("failed to commit kafka offset", "error", err, "topic", s.cfg.Topic)
+		}
+	}
+}
+
+// process decodes and processes a single message, retrying on failure and
+// falling back to the dead-letter topic once retries are exhausted.
+func (s *KafkaSource) process(ctx context.Context, msg kafka.Message) {
+	var event models.VehicleEvent
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		s.logger.Error("failed to decode kafka message", "error", err, "topic", s.cfg.Topic)
+		s.deadLetter(ctx, msg, err)
+		return
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= maxProcessRetries; attempt++ {
+		if attempt > 0 {
+			s.metrics.RecordKafkaRetry(s.cfg.Topic)
+		}
+		if lastErr = s.processor.ProcessEvent(ctx, &event); lastErr == nil {
+			return
+		}
+		s.logger.Warn("kafka event processing failed, retrying",
+			"error", lastErr,
+			"event_id", event.EventID,
+			"attempt", attempt+1,
+		)
+	}
+
+	s.deadLetter(ctx, msg, lastErr)
+}
+
+// deadLetter writes msg to the configured dead-letter topic, if any.
+func (s *KafkaSource) deadLetter(ctx context.Context, msg kafka.Message, cause error) {
+	if s.dlqWriter == nil {
+		s.logger.Error("dropping unprocessable kafka message, no DLQ configured", "error", cause, "topic", s.cfg.Topic)
+		return
+	}
+
+	if err := s.dlqWriter.WriteMessages(ctx, kafka.Message{Key: msg.Key, Value: msg.Value}); err != nil {
+		s.logger.Error("failed to write to dead-letter topic", "error", err, "dlq_topic", s.cfg.DLQTopic)
+		return
+	}
+	s.metrics.RecordKafkaDLQWrite(s.cfg.Topic)
+}
+
+// reportLag periodically publishes the reader's consumer lag until ctx is
+// cancelled.
+func (s *KafkaSource) reportLag(ctx context.Context) {
+	ticker := time.NewTicker(15 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.metrics.UpdateKafkaConsumerLag(s.cfg.Topic, float64(s.reader.Stats().Lag))
+		}
+	}
+}
+
+// Stop closes the reader and DLQ writer, flushing any in-flight commits.
+func (s *KafkaSource) Stop(ctx context.Context) error {
+	if s.dlqWriter != nil {
+		if err := s.dlqWriter.Close(); err != nil {
+			s.logger.Error("failed to close DLQ writer", "error", err)
+		}
+	}
+	return s.reader.Close()
+}
@@ -0,0 +1,24 @@
+// Package ingest defines the pluggable entry points that feed events into
+// the processing pipeline. Every concrete source (HTTP, Kafka, ...)
+// implements EventSource and pushes validated events through the same
+// processor.EventProcessor.ProcessEvent call, so downstream behavior
+// (validation, metrics, WebSocket fan-out) is identical regardless of where
+// an event came from.
+package ingest
+
+import "context"
+
+// EventSource is a goroutine-driven source of events. Start blocks until
+// the source is shut down or an unrecoverable error occurs; Stop requests a
+// graceful shutdown and blocks until it completes or ctx expires.
+type EventSource interface {
+	// Name identifies the source for logging, e.g. "http" or "kafka".
+	Name() string
+	// Start runs the source until it is shut down. It returns nil on a
+	// clean shutdown, or an error if the source failed. KafkaSource stops
+	// when ctx is cancelled; HTTPSource ignores ctx and stops only when
+	// Stop is called.
+	Start(ctx context.Context) error
+	// Stop gracefully shuts the source down, respecting ctx's deadline.
+	Stop(ctx context.Context) error
+}
@@ -5,28 +5,70 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
+// MetricsConfig controls which label sets NewMetrics registers.
+type MetricsConfig struct {
+	// PerVehicleLabels opts into a vehicle_id-labelled events counter, in
+	// addition to the aggregate event_type/fleet_id/region counters. This
+	// is only safe for small deployments: Prometheus cardinality grows
+	// linearly with the number of distinct vehicles, so it defaults to
+	// false (aggregate-only).
+	PerVehicleLabels bool
+}
+
 // Metrics struct holds Prometheus metrics collectors
 type Metrics struct {
 	EventsProcessed   *prometheus.CounterVec
 	EventsValidation  *prometheus.CounterVec
 	ProcessingLatency *prometheus.HistogramVec
 	ActiveVehicles    prometheus.Gauge
+
+	HTTPRequestsTotal    *prometheus.CounterVec
+	HTTPRequestDuration  *prometheus.HistogramVec
+	HTTPRequestsInFlight prometheus.Gauge
+	HTTPResponseSize     *prometheus.HistogramVec
+
+	// PerVehicleEvents is only non-nil when MetricsConfig.PerVehicleLabels
+	// is set; RecordVehicleEvent is a no-op otherwise.
+	PerVehicleEvents *prometheus.CounterVec
+
+	WSConnections        prometheus.Gauge
+	WSDroppedEventsTotal prometheus.Counter
+
+	KafkaConsumerLag    *prometheus.GaugeVec
+	KafkaRetriesTotal   *prometheus.CounterVec
+	KafkaDLQWritesTotal *prometheus.CounterVec
+
+	QueueDepth        prometheus.Gauge
+	QueueDroppedTotal prometheus.Counter
+	WorkerBusy        prometheus.Gauge
+
+	MetricsAuthFailuresTotal prometheus.Counter
 }
 
-// NewMetrics initializes and returns a Metrics instance
-// Returns a new Metrics instance with all Prometheus metrics initialized
-// Each metric is defined with appropriate labels and help descriptions.
-func NewMetrics() *Metrics {
-	return &Metrics{
-		EventsProcessed: promauto.NewCounterVec(
+// NewMetrics initializes and returns a Metrics instance, registering all
+// collectors on reg instead of the process-global default registry. This
+// lets callers spin up multiple independent Metrics instances (e.g. one per
+// test) without duplicate-registration panics, and lets integrators embed
+// the fleet processor inside a larger binary that already owns its own
+// registry. cfg controls whether the higher-cardinality per-vehicle counter
+// is registered at all; see MetricsConfig.
+// parameters: reg prometheus.Registerer: The registry to register collectors on.
+//
+//	cfg MetricsConfig: Controls optional higher-cardinality label sets.
+//
+// returns: *Metrics: A new Metrics instance with all Prometheus metrics initialized.
+func NewMetrics(reg prometheus.Registerer, cfg MetricsConfig) *Metrics {
+	factory := promauto.With(reg)
+	m := &Metrics{
+		EventsProcessed: factory.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "fleet_events_processed_total",
-				Help: "Total number of vehicle events processed",
+				Help: "Total number of vehicle events processed, aggregated by event type, fleet, and region",
 			},
-			[]string{"event_type", "vehicle_id"},
+			[]string{"event_type", "fleet_id", "region"},
 		),
 
-		EventsValidation: promauto.NewCounterVec(
+		EventsValidation: factory.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "fleet_events_validation_total",
 				Help: "Total number of validation results",
@@ -35,7 +77,7 @@ func NewMetrics() *Metrics {
 			[]string{"status"},
 		),
 
-		ProcessingLatency: promauto.NewHistogramVec(
+		ProcessingLatency: factory.NewHistogramVec(
 			prometheus.HistogramOpts{
 				Name:    "fleet_event_processing_duration_seconds",
 				Help:    "Event processing latency in seconds",
@@ -44,24 +86,149 @@ func NewMetrics() *Metrics {
 			[]string{"event_type"},
 		),
 
-		ActiveVehicles: promauto.NewGauge(
+		ActiveVehicles: factory.NewGauge(
 			prometheus.GaugeOpts{
 				Name: "fleet_active_vehicles",
 				Help: "Number of active vehicles currently tracked",
 			},
 		),
+
+		HTTPRequestsTotal: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "fleet_http_requests_total",
+				Help: "Total number of HTTP requests handled",
+			},
+			[]string{"method", "path", "status"},
+		),
+
+		HTTPRequestDuration: factory.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "fleet_http_request_duration_seconds",
+				Help:    "HTTP request latency in seconds",
+				Buckets: prometheus.DefBuckets,
+			},
+			[]string{"method", "path", "status"},
+		),
+
+		HTTPRequestsInFlight: factory.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "fleet_http_requests_in_flight",
+				Help: "Number of HTTP requests currently being served",
+			},
+		),
+
+		HTTPResponseSize: factory.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "fleet_http_response_size_bytes",
+				Help:    "HTTP response size in bytes",
+				Buckets: prometheus.ExponentialBuckets(100, 10, 6),
+			},
+			[]string{"method", "path", "status"},
+		),
+	}
+
+	m.WSConnections = factory.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "fleet_ws_connections",
+			Help: "Number of currently connected WebSocket subscribers",
+		},
+	)
+
+	m.WSDroppedEventsTotal = factory.NewCounter(
+		prometheus.CounterOpts{
+			Name: "fleet_ws_dropped_events_total",
+			Help: "Total number of events dropped because a subscriber's outbound queue was full",
+		},
+	)
+
+	m.KafkaConsumerLag = factory.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "fleet_kafka_consumer_lag",
+			Help: "Consumer lag reported by the Kafka reader, per topic",
+		},
+		[]string{"topic"},
+	)
+
+	m.KafkaRetriesTotal = factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fleet_kafka_retries_total",
+			Help: "Total number of Kafka message processing retries",
+		},
+		[]string{"topic"},
+	)
+
+	m.KafkaDLQWritesTotal = factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fleet_kafka_dlq_writes_total",
+			Help: "Total number of messages written to the dead-letter topic, either undecodable or failing every processing attempt",
+		},
+		[]string{"topic"},
+	)
+
+	m.QueueDepth = factory.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "fleet_queue_depth",
+			Help: "Current number of events waiting in the worker pool queue",
+		},
+	)
+
+	m.QueueDroppedTotal = factory.NewCounter(
+		prometheus.CounterOpts{
+			Name: "fleet_queue_dropped_total",
+			Help: "Total number of events rejected because the worker pool queue was full",
+		},
+	)
+
+	m.WorkerBusy = factory.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "fleet_worker_busy",
+			Help: "Fraction of worker pool goroutines currently processing an event",
+		},
+	)
+
+	m.MetricsAuthFailuresTotal = factory.NewCounter(
+		prometheus.CounterOpts{
+			Name: "fleet_metrics_auth_failures_total",
+			Help: "Total number of rejected authentication attempts against the metrics endpoint",
+		},
+	)
+
+	if cfg.PerVehicleLabels {
+		m.PerVehicleEvents = factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "fleet_events_processed_per_vehicle_total",
+				Help: "Total number of vehicle events processed, labelled by vehicle_id. Only registered when MetricsConfig.PerVehicleLabels is set; unsafe for large fleets.",
+			},
+			[]string{"vehicle_id"},
+		)
 	}
+
+	return m
 }
 
-// RecordEventProcessed increments the EventsProcessed counter
-// for a given event type and vehicle ID.
+// RecordEventProcessed increments the aggregate EventsProcessed counter for
+// a given event type, fleet, and region. It never carries a vehicle_id
+// label, so its cardinality stays bounded regardless of fleet size.
 // parameters: eventType string: The type of the event processed.
 //
-//	vehicleID string: The ID of the vehicle associated with the event.
+//	fleetID string: The fleet the vehicle belongs to, if known.
+//	region string: The region the event originated from, if known.
 //
 // returns: none
-func (m *Metrics) RecordEventProcessed(eventType, vehicleID string) {
-	m.EventsProcessed.WithLabelValues(eventType, vehicleID).Inc()
+func (m *Metrics) RecordEventProcessed(eventType, fleetID, region string) {
+	m.EventsProcessed.WithLabelValues(eventType, fleetID, region).Inc()
+}
+
+// RecordVehicleEvent increments the optional per-vehicle events counter. It
+// is a no-op unless the Metrics instance was built with
+// MetricsConfig.PerVehicleLabels set.
+// parameters: vehicleID string: The ID of the vehicle associated with the event.
+// returns: none
+func (m *Metrics) RecordVehicleEvent(vehicleID string) {
+	if m.PerVehicleEvents == nil {
+		return
+	}
+	m.PerVehicleEvents.WithLabelValues(vehicleID).Inc()
 }
 
 // RecordValidation increments the EventsValidation counter
@@ -88,6 +255,23 @@ func (m *Metrics) RecordProcessingDuration(eventType string, duration float64) {
 	m.ProcessingLatency.WithLabelValues(eventType).Observe(duration)
 }
 
+// RecordHTTPRequest records the outcome of a single HTTP request: it
+// increments the request counter, observes the duration and response size
+// histograms, all partitioned by method, path, and status code.
+// parameters: method string: The HTTP method of the request.
+//
+//	path string: The request path.
+//	status string: The HTTP status code, as a string.
+//	duration float64: The request duration in seconds.
+//	responseSize float64: The response size in bytes.
+//
+// returns: none
+func (m *Metrics) RecordHTTPRequest(method, path, status string, duration, responseSize float64) {
+	m.HTTPRequestsTotal.WithLabelValues(method, path, status).Inc()
+	m.HTTPRequestDuration.WithLabelValues(method, path, status).Observe(duration)
+	m.HTTPResponseSize.WithLabelValues(method, path, status).Observe(responseSize)
+}
+
 // UpdateActiveVehicles sets the ActiveVehicles gauge
 // to the current count of active vehicles.
 // parameters: count float64: The current number of active vehicles.
@@ -96,3 +280,75 @@ func (m *Metrics) UpdateActiveVehicles(count float64) {
 	m.ActiveVehicles.Set(count)
 
 }
+
+// UpdateWSConnections sets the WSConnections gauge to the current number of
+// connected WebSocket subscribers.
+// parameters: count float64: The current number of connected subscribers.
+// returns: none
+func (m *Metrics) UpdateWSConnections(count float64) {
+	m.WSConnections.Set(count)
+}
+
+// RecordWSDroppedEvent increments the WSDroppedEventsTotal counter when an
+// event is dropped for a subscriber whose outbound queue was full.
+// parameters: none
+// returns: none
+func (m *Metrics) RecordWSDroppedEvent() {
+	m.WSDroppedEventsTotal.Inc()
+}
+
+// UpdateKafkaConsumerLag sets the KafkaConsumerLag gauge for topic.
+// parameters: topic string: The Kafka topic being consumed.
+//
+//	lag float64: The current consumer lag, in messages.
+//
+// returns: none
+func (m *Metrics) UpdateKafkaConsumerLag(topic string, lag float64) {
+	m.KafkaConsumerLag.WithLabelValues(topic).Set(lag)
+}
+
+// RecordKafkaRetry increments the KafkaRetriesTotal counter for topic.
+// parameters: topic string: The Kafka topic being consumed.
+// returns: none
+func (m *Metrics) RecordKafkaRetry(topic string) {
+	m.KafkaRetriesTotal.WithLabelValues(topic).Inc()
+}
+
+// RecordKafkaDLQWrite increments the KafkaDLQWritesTotal counter for topic.
+// parameters: topic string: The source Kafka topic whose message was dead-lettered.
+// returns: none
+func (m *Metrics) RecordKafkaDLQWrite(topic string) {
+	m.KafkaDLQWritesTotal.WithLabelValues(topic).Inc()
+}
+
+// UpdateQueueDepth sets the QueueDepth gauge to the worker pool's current
+// queue length.
+// parameters: depth float64: The current number of queued events.
+// returns: none
+func (m *Metrics) UpdateQueueDepth(depth float64) {
+	m.QueueDepth.Set(depth)
+}
+
+// RecordQueueDropped increments the QueueDroppedTotal counter when an event
+// is rejected because the worker pool queue was full.
+// parameters: none
+// returns: none
+func (m *Metrics) RecordQueueDropped() {
+	m.QueueDroppedTotal.Inc()
+}
+
+// UpdateWorkerBusy sets the WorkerBusy gauge to the fraction of worker pool
+// goroutines currently processing an event.
+// parameters: fraction float64: The busy fraction, between 0 and 1.
+// returns: none
+func (m *Metrics) UpdateWorkerBusy(fraction float64) {
+	m.WorkerBusy.Set(fraction)
+}
+
+// RecordMetricsAuthFailure increments the MetricsAuthFailuresTotal counter
+// when a request to the metrics endpoint fails authentication.
+// parameters: none
+// returns: none
+func (m *Metrics) RecordMetricsAuthFailure() {
+	m.MetricsAuthFailuresTotal.Inc()
+}
@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// BenchmarkRecordEventProcessed_Aggregate exercises the default,
+// aggregate-only label set (event_type/fleet_id/region), which stays
+// bounded regardless of fleet size.
+func BenchmarkRecordEventProcessed_Aggregate(b *testing.B) {
+	m := NewMetrics(prometheus.NewRegistry(), MetricsConfig{})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.RecordEventProcessed("position", "fleet-1", "us-west")
+	}
+}
+
+// BenchmarkRecordEventProcessed_PerVehicle exercises the opt-in
+// vehicle_id-labelled counter across a simulated fleet of 10,000 distinct
+// vehicles, showing the allocation and series-growth cost that motivated
+// moving per-vehicle counts out of Prometheus labels.
+func BenchmarkRecordEventProcessed_PerVehicle(b *testing.B) {
+	const fleetSize = 10_000
+	m := NewMetrics(prometheus.NewRegistry(), MetricsConfig{PerVehicleLabels: true})
+
+	vehicleIDs := make([]string, fleetSize)
+	for i := range vehicleIDs {
+		vehicleIDs[i] = fmt.Sprintf("vehicle-%d", i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.RecordVehicleEvent(vehicleIDs[i%fleetSize])
+	}
+}
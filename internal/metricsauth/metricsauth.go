@@ -0,0 +1,74 @@
+// Package metricsauth provides optional basic-auth / bearer-token
+// protection for the metrics endpoint, which otherwise exposes fleet
+// composition (and, if MetricsConfig.PerVehicleLabels is set, per-vehicle
+// data) to anything that can reach the metrics port.
+package metricsauth
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/saisudhir14/fleet-event-stream/internal/metrics"
+)
+
+// Config holds the credentials accepted by Middleware. Basic auth and
+// bearer token can both be configured; a request is allowed through if it
+// satisfies either one.
+type Config struct {
+	BasicUser   string
+	BasicPass   string
+	BearerToken string
+}
+
+// enabled reports whether any credentials are configured. When none are,
+// Middleware skips authentication entirely to preserve the zero-config
+// developer experience.
+func (c Config) enabled() bool {
+	return (c.BasicUser != "" && c.BasicPass != "") || c.BearerToken != ""
+}
+
+// Middleware wraps next with basic-auth and/or bearer-token checks per cfg.
+// If cfg has no credentials configured, next is returned unwrapped. Failed
+// attempts increment m.MetricsAuthFailuresTotal.
+func Middleware(cfg Config, m *metrics.Metrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !cfg.enabled() {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if cfg.BearerToken != "" && bearerTokenMatches(r, cfg.BearerToken) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if cfg.BasicUser != "" && cfg.BasicPass != "" {
+				if user, pass, ok := r.BasicAuth(); ok && credentialsMatch(user, pass, cfg.BasicUser, cfg.BasicPass) {
+					next.ServeHTTP(w, r)
+					return
+				}
+				w.Header().Set("WWW-Authenticate", `Basic realm="metrics"`)
+			}
+
+			m.RecordMetricsAuthFailure()
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+		})
+	}
+}
+
+func bearerTokenMatches(r *http.Request, want string) bool {
+	const prefix = "Bearer "
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, prefix) {
+		return false
+	}
+	got := strings.TrimPrefix(auth, prefix)
+	return subtle.ConstantTimeCompare([]byte(got), []byte(want)) == 1
+}
+
+func credentialsMatch(gotUser, gotPass, wantUser, wantPass string) bool {
+	userOK := subtle.ConstantTimeCompare([]byte(gotUser), []byte(wantUser)) == 1
+	passOK := subtle.ConstantTimeCompare([]byte(gotPass), []byte(wantPass)) == 1
+	return userOK && passOK
+}
@@ -0,0 +1,168 @@
+package metricsauth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/saisudhir14/fleet-event-stream/internal/metrics"
+)
+
+func newTestMetrics(t *testing.T) *metrics.Metrics {
+	t.Helper()
+	return metrics.NewMetrics(prometheus.NewRegistry(), metrics.MetricsConfig{})
+}
+
+func okHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func TestMiddleware_Unconfigured(t *testing.T) {
+	m := newTestMetrics(t)
+	handler := Middleware(Config{}, m)(okHandler())
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("unconfigured Config: got status %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestMiddleware_BasicAuth(t *testing.T) {
+	tests := []struct {
+		name       string
+		user, pass string
+		setAuth    bool
+		wantStatus int
+	}{
+		{name: "correct credentials", user: "admin", pass: "secret", setAuth: true, wantStatus: http.StatusOK},
+		{name: "wrong password", user: "admin", pass: "wrong", setAuth: true, wantStatus: http.StatusUnauthorized},
+		{name: "wrong user", user: "nobody", pass: "secret", setAuth: true, wantStatus: http.StatusUnauthorized},
+		{name: "missing authorization header", setAuth: false, wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestMetrics(t)
+			cfg := Config{BasicUser: "admin", BasicPass: "secret"}
+			handler := Middleware(cfg, m)(okHandler())
+
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if tt.setAuth {
+				req.SetBasicAuth(tt.user, tt.pass)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+				t.Error("expected WWW-Authenticate header on 401")
+			}
+		})
+	}
+}
+
+func TestMiddleware_BearerToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		authHeader string
+		wantStatus int
+	}{
+		{name: "correct token", authHeader: "Bearer good-token", wantStatus: http.StatusOK},
+		{name: "wrong token", authHeader: "Bearer bad-token", wantStatus: http.StatusUnauthorized},
+		{name: "garbled header", authHeader: "garbage", wantStatus: http.StatusUnauthorized},
+		{name: "missing authorization header", authHeader: "", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestMetrics(t)
+			cfg := Config{BearerToken: "good-token"}
+			handler := Middleware(cfg, m)(okHandler())
+
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestMiddleware_BothConfigured_EitherSatisfies(t *testing.T) {
+	cfg := Config{BasicUser: "admin", BasicPass: "secret", BearerToken: "good-token"}
+
+	t.Run("bearer token satisfies", func(t *testing.T) {
+		m := newTestMetrics(t)
+		handler := Middleware(cfg, m)(okHandler())
+
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		req.Header.Set("Authorization", "Bearer good-token")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusOK)
+		}
+	})
+
+	t.Run("basic auth satisfies", func(t *testing.T) {
+		m := newTestMetrics(t)
+		handler := Middleware(cfg, m)(okHandler())
+
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		req.SetBasicAuth("admin", "secret")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusOK)
+		}
+	})
+
+	t.Run("neither satisfies", func(t *testing.T) {
+		m := newTestMetrics(t)
+		handler := Middleware(cfg, m)(okHandler())
+
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+func TestConfig_Enabled(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want bool
+	}{
+		{name: "empty config", cfg: Config{}, want: false},
+		{name: "basic user without password", cfg: Config{BasicUser: "admin"}, want: false},
+		{name: "basic user and password", cfg: Config{BasicUser: "admin", BasicPass: "secret"}, want: true},
+		{name: "bearer token only", cfg: Config{BearerToken: "good-token"}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.enabled(); got != tt.want {
+				t.Errorf("enabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -16,6 +16,8 @@ type VehicleEvent struct {
 	Timestamp time.Time `json:"timestamp"`
 	EventType string    `json:"event_type"`
 	DriverID  string    `json:"driver_id,omitempty"`
+	FleetID   string    `json:"fleet_id,omitempty"`
+	Region    string    `json:"region,omitempty"`
 }
 
 // Validate checks if the VehicleEvent has all required fields and valid values
@@ -2,29 +2,174 @@ package processor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/saisudhir14/fleet-event-stream/internal/metrics"
 	"github.com/saisudhir14/fleet-event-stream/internal/models"
 )
 
+// Publisher receives every event that EventProcessor has successfully
+// processed, for fan-out to external subscribers (e.g. WebSocket clients).
+// Implementations must not block: Publish is called inline on the
+// processing path.
+type Publisher interface {
+	Publish(event *models.VehicleEvent)
+}
+
+// ErrQueueFull is returned by Enqueue when the worker pool's queue is at
+// capacity. Callers (typically an HTTP handler) should surface this as a
+// 429 with a Retry-After header rather than blocking the caller's goroutine.
+var ErrQueueFull = errors.New("processing queue is full")
+
+const (
+	defaultWorkerCount = 4
+	defaultQueueDepth  = 256
+)
+
+// PoolConfig configures the bounded worker pool that drains queued events.
+type PoolConfig struct {
+	// WorkerCount is the number of goroutines draining the queue. A
+	// non-positive value uses defaultWorkerCount.
+	WorkerCount int
+	// QueueDepth is the capacity of the bounded event queue. A
+	// non-positive value uses defaultQueueDepth.
+	QueueDepth int
+}
+
 // EventProcessor handles the processing of vehicle events. It maintains
 // per-vehicle event counts and provides thread-safe access to statistics.
+// Events submitted via Enqueue are processed by a bounded pool of worker
+// goroutines so a burst of traffic backpressures the caller (via
+// ErrQueueFull) instead of growing memory or capping throughput at the
+// HTTP server's own concurrency limit.
 type EventProcessor struct {
 	mu         sync.RWMutex
 	eventCount map[string]int64
 	logger     *slog.Logger
+	publisher  Publisher
+	metrics    *metrics.Metrics
+
+	queue       chan *models.VehicleEvent
+	workerCount int
+	busyWorkers int32
+	wg          sync.WaitGroup
 }
 
-// NewEventProcessor creates a new EventProcessor instance
+// NewEventProcessor creates a new EventProcessor instance. m may be nil, in
+// which case queue/worker metrics are simply not recorded.
 // parameters: logger *slog.Logger: The logger instance.
 //
+//	m *metrics.Metrics: The metrics collector instance, or nil.
+//	cfg PoolConfig: Worker pool sizing; zero-valued fields use their defaults.
+//
 // returns: *EventProcessor: A new EventProcessor instance.
-func NewEventProcessor(logger *slog.Logger) *EventProcessor {
+func NewEventProcessor(logger *slog.Logger, m *metrics.Metrics, cfg PoolConfig) *EventProcessor {
+	if cfg.WorkerCount <= 0 {
+		cfg.WorkerCount = defaultWorkerCount
+	}
+	if cfg.QueueDepth <= 0 {
+		cfg.QueueDepth = defaultQueueDepth
+	}
+
 	return &EventProcessor{
-		eventCount: make(map[string]int64),
-		logger:     logger,
+		eventCount:  make(map[string]int64),
+		logger:      logger,
+		metrics:     m,
+		queue:       make(chan *models.VehicleEvent, cfg.QueueDepth),
+		workerCount: cfg.WorkerCount,
+	}
+}
+
+// SetPublisher wires a Publisher that receives every successfully processed
+// event. It is optional; a nil or never-set publisher means events are
+// simply not fanned out.
+// parameters: publisher Publisher: The publisher to dispatch processed events to.
+// returns: none
+func (p *EventProcessor) SetPublisher(publisher Publisher) {
+	p.publisher = publisher
+}
+
+// Start launches the worker pool. Workers run until Stop closes the queue;
+// cancelling ctx does not stop them, it is only passed on to ProcessEvent.
+// parameters: ctx context.Context: The context governing the workers' lifetime.
+// returns: none
+func (p *EventProcessor) Start(ctx context.Context) {
+	for i := 0; i < p.workerCount; i++ {
+		p.wg.Add(1)
+		go p.worker(ctx)
+	}
+}
+
+// Stop closes the queue and waits for in-flight events to finish
+// processing. Enqueue must not be called after Stop.
+// parameters: none
+// returns: none
+func (p *EventProcessor) Stop() {
+	close(p.queue)
+	p.wg.Wait()
+}
+
+// worker drains the queue until it is closed by Stop. It deliberately does
+// not exit early on ctx cancellation: Stop is responsible for an orderly
+// shutdown that finishes everything already queued, while ctx is passed
+// through to ProcessEvent for context-scoped concerns (e.g. deadlines).
+func (p *EventProcessor) worker(ctx context.Context) {
+	defer p.wg.Done()
+	for event := range p.queue {
+		p.runWorker(ctx, event)
+	}
+}
+
+func (p *EventProcessor) runWorker(ctx context.Context, event *models.VehicleEvent) {
+	busy := atomic.AddInt32(&p.busyWorkers, 1)
+	if p.metrics != nil {
+		p.metrics.UpdateWorkerBusy(float64(busy) / float64(p.workerCount))
+	}
+	defer func() {
+		busy := atomic.AddInt32(&p.busyWorkers, -1)
+		if p.metrics != nil {
+			p.metrics.UpdateWorkerBusy(float64(busy) / float64(p.workerCount))
+			p.metrics.UpdateQueueDepth(float64(len(p.queue)))
+		}
+	}()
+
+	if err := p.ProcessEvent(ctx, event); err != nil {
+		p.logger.Error("queued event processing failed", "error", err, "event_id", event.EventID)
+	}
+}
+
+// Enqueue validates event and hands it to the worker pool for asynchronous
+// processing. It returns immediately: a non-nil error means the event was
+// rejected outright (invalid payload, or ErrQueueFull if the pool is
+// saturated) rather than that processing itself failed.
+// parameters: event *models.VehicleEvent: The vehicle event to enqueue.
+//
+// returns: error: A validation error, ErrQueueFull, or nil on successful enqueue.
+func (p *EventProcessor) Enqueue(event *models.VehicleEvent) error {
+	if err := event.Validate(); err != nil {
+		if p.metrics != nil {
+			p.metrics.RecordValidation(false)
+		}
+		return fmt.Errorf("validation error: %w", err)
+	}
+
+	select {
+	case p.queue <- event:
+		if p.metrics != nil {
+			p.metrics.UpdateQueueDepth(float64(len(p.queue)))
+		}
+		return nil
+	default:
+		if p.metrics != nil {
+			p.metrics.RecordQueueDropped()
+		}
+		return ErrQueueFull
 	}
 }
 
@@ -35,6 +180,7 @@ func NewEventProcessor(logger *slog.Logger) *EventProcessor {
 //
 // returns: error: An error if processing fails, nil otherwise.
 func (p *EventProcessor) ProcessEvent(ctx context.Context, event *models.VehicleEvent) error {
+	start := time.Now()
 
 	if err := event.Validate(); err != nil {
 		p.logger.Error("event validation failed",
@@ -42,6 +188,9 @@ func (p *EventProcessor) ProcessEvent(ctx context.Context, event *models.Vehicle
 			"event_id", event.EventID,
 			"vehicle_id", event.VehicleID,
 		)
+		if p.metrics != nil {
+			p.metrics.RecordValidation(false)
+		}
 		return fmt.Errorf("validation error: %w", err)
 	}
 
@@ -56,6 +205,15 @@ func (p *EventProcessor) ProcessEvent(ctx context.Context, event *models.Vehicle
 		p.handleGenericEvent(event)
 	}
 	p.incrementEventCount(event.VehicleID)
+	if p.publisher != nil {
+		p.publisher.Publish(event)
+	}
+	if p.metrics != nil {
+		p.metrics.RecordValidation(true)
+		p.metrics.RecordEventProcessed(event.EventType, event.FleetID, event.Region)
+		p.metrics.RecordVehicleEvent(event.VehicleID)
+		p.metrics.RecordProcessingDuration(event.EventType, time.Since(start).Seconds())
+	}
 	p.logger.Info("event processed successfully",
 		"event_id", event.EventID,
 		"vehicle_id", event.VehicleID,
@@ -138,3 +296,59 @@ func (p *EventProcessor) GetTotalEventCount() int64 {
 	}
 	return total
 }
+
+// VehicleCount pairs a vehicle ID with its processed event count.
+type VehicleCount struct {
+	VehicleID string `json:"vehicle_id"`
+	Count     int64  `json:"count"`
+}
+
+// TopVehicles returns the n vehicles with the highest event counts, sorted
+// in descending order. A non-positive n returns all vehicles sorted the
+// same way.
+// parameters: n int: The maximum number of vehicles to return.
+//
+// returns: []VehicleCount: The top vehicles by event count.
+func (p *EventProcessor) TopVehicles(n int) []VehicleCount {
+	p.mu.RLock()
+	counts := make([]VehicleCount, 0, len(p.eventCount))
+	for vehicleID, count := range p.eventCount {
+		counts = append(counts, VehicleCount{VehicleID: vehicleID, Count: count})
+	}
+	p.mu.RUnlock()
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].VehicleID < counts[j].VehicleID
+	})
+
+	if n > 0 && n < len(counts) {
+		counts = counts[:n]
+	}
+	return counts
+}
+
+// VehiclesPage returns a page of vehicle counts, sorted in descending order
+// by count, along with the total number of tracked vehicles. page is
+// 1-indexed; an out-of-range page returns an empty slice.
+// parameters: page int: The 1-indexed page number.
+//
+//	pageSize int: The number of vehicles per page.
+//
+// returns: ([]VehicleCount, int): The requested page and the total vehicle count.
+func (p *EventProcessor) VehiclesPage(page, pageSize int) ([]VehicleCount, int) {
+	counts := p.TopVehicles(0)
+	total := len(counts)
+
+	start := (page - 1) * pageSize
+	if start < 0 || start >= total {
+		return []VehicleCount{}, total
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+	return counts[start:end], total
+}
@@ -0,0 +1,81 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/saisudhir14/fleet-event-stream/internal/metrics"
+	"github.com/saisudhir14/fleet-event-stream/internal/models"
+)
+
+func newTestProcessor(t *testing.T, cfg PoolConfig) *EventProcessor {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	m := metrics.NewMetrics(prometheus.NewRegistry(), metrics.MetricsConfig{})
+	return NewEventProcessor(logger, m, cfg)
+}
+
+func validEvent(eventID string) *models.VehicleEvent {
+	return &models.VehicleEvent{
+		EventID:   eventID,
+		VehicleID: "vehicle-1",
+		EventType: models.EventTypePosition,
+		Timestamp: time.Now(),
+	}
+}
+
+// TestEventProcessor_EnqueueBackpressure confirms Enqueue rejects with
+// ErrQueueFull once the bounded queue is saturated, without blocking the
+// caller, rather than growing unbounded or waiting for a worker.
+func TestEventProcessor_EnqueueBackpressure(t *testing.T) {
+	p := newTestProcessor(t, PoolConfig{WorkerCount: 1, QueueDepth: 1})
+
+	if err := p.Enqueue(validEvent("evt-1")); err != nil {
+		t.Fatalf("first enqueue: got error %v, want nil", err)
+	}
+
+	err := p.Enqueue(validEvent("evt-2"))
+	if !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("second enqueue: got error %v, want ErrQueueFull", err)
+	}
+}
+
+func TestEventProcessor_EnqueueValidationError(t *testing.T) {
+	p := newTestProcessor(t, PoolConfig{})
+
+	err := p.Enqueue(&models.VehicleEvent{})
+	if err == nil {
+		t.Fatal("got nil error, want a validation error")
+	}
+	if errors.Is(err, ErrQueueFull) {
+		t.Error("validation error should not be ErrQueueFull")
+	}
+}
+
+// TestEventProcessor_StartDrainsQueue confirms a started worker pool drains
+// enqueued events, unblocking further Enqueue calls that would otherwise
+// hit ErrQueueFull.
+func TestEventProcessor_StartDrainsQueue(t *testing.T) {
+	p := newTestProcessor(t, PoolConfig{WorkerCount: 1, QueueDepth: 5})
+	p.Start(context.Background())
+	defer p.Stop()
+
+	for i := 0; i < 5; i++ {
+		if err := p.Enqueue(validEvent("evt")); err != nil {
+			t.Fatalf("enqueue %d: got error %v, want nil", i, err)
+		}
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for p.GetTotalEventCount() < 5 {
+		if time.Now().After(deadline) {
+			t.Fatalf("got %d processed events, want 5", p.GetTotalEventCount())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,82 @@
+package stream
+
+import (
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	writeWait      = 10 * time.Second
+	pongWait       = 60 * time.Second
+	pingPeriod     = pongWait * 9 / 10
+	maxMessageSize = 4096
+)
+
+// client is a single subscriber connection. Its outbound queue is bounded
+// so a slow reader can never block the ingest path; once it fills, Publish
+// drops the message for that client and counts it as dropped rather than
+// blocking.
+type client struct {
+	hub    *Hub
+	conn   *websocket.Conn
+	send   chan []byte
+	filter Filter
+}
+
+// writePump relays queued messages to the socket and periodically pings the
+// client to detect dead connections. It owns all writes to conn — Shutdown
+// and unregister only ever close(c.send), never write to conn themselves —
+// and exits (closing the connection) when send is closed by the hub, which
+// also signals hub.wg that this client has fully drained.
+func (c *client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+		c.hub.wg.Done()
+	}()
+
+	for {
+		select {
+		case message, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readPump discards any client-sent frames (this endpoint is publish-only
+// to subscribers) but keeps the connection's read deadline alive so pongs
+// are observed; it exits and unregisters the client once the connection
+// errors or closes.
+func (c *client) readPump() {
+	defer func() {
+		c.hub.unregister(c)
+		c.conn.Close()
+	}()
+
+	c.conn.SetReadLimit(maxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		c.conn.SetReadDeadline(time.Now().Add(pongWait))
+		return nil
+	})
+
+	for {
+		if _, _, err := c.conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,8 @@
+package stream
+
+import "errors"
+
+var (
+	errIncompleteBBox = errors.New("bounding box filter requires min_lat, max_lat, min_lon, and max_lon together")
+	errInvalidBBox    = errors.New("bounding box filter values must be valid floating-point numbers")
+)
@@ -0,0 +1,85 @@
+package stream
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/saisudhir14/fleet-event-stream/internal/models"
+)
+
+// BoundingBox is an inclusive lat/lon rectangle used to filter events by
+// location.
+type BoundingBox struct {
+	MinLat float64
+	MaxLat float64
+	MinLon float64
+	MaxLon float64
+}
+
+// Contains reports whether (lat, lon) falls within the bounding box.
+func (b BoundingBox) Contains(lat, lon float64) bool {
+	return lat >= b.MinLat && lat <= b.MaxLat && lon >= b.MinLon && lon <= b.MaxLon
+}
+
+// Filter narrows the set of events a subscriber receives. Zero-valued
+// fields are treated as "match anything" for that dimension.
+type Filter struct {
+	VehicleID string
+	EventType string
+	BBox      *BoundingBox
+}
+
+// Match reports whether event satisfies every configured dimension of f.
+func (f Filter) Match(event *models.VehicleEvent) bool {
+	if f.VehicleID != "" && f.VehicleID != event.VehicleID {
+		return false
+	}
+	if f.EventType != "" && f.EventType != event.EventType {
+		return false
+	}
+	if f.BBox != nil && !f.BBox.Contains(event.Latitude, event.Longitude) {
+		return false
+	}
+	return true
+}
+
+// ParseFilter builds a Filter from subscribe request query parameters:
+// vehicle_id, event_type, and min_lat/max_lat/min_lon/max_lon (all four
+// required together to activate the bounding-box filter).
+func ParseFilter(query url.Values) (Filter, error) {
+	f := Filter{
+		VehicleID: query.Get("vehicle_id"),
+		EventType: query.Get("event_type"),
+	}
+
+	bboxKeys := []string{"min_lat", "max_lat", "min_lon", "max_lon"}
+	present := 0
+	for _, k := range bboxKeys {
+		if query.Has(k) {
+			present++
+		}
+	}
+	if present == 0 {
+		return f, nil
+	}
+	if present != len(bboxKeys) {
+		return Filter{}, errIncompleteBBox
+	}
+
+	values := make(map[string]float64, len(bboxKeys))
+	for _, k := range bboxKeys {
+		v, err := strconv.ParseFloat(query.Get(k), 64)
+		if err != nil {
+			return Filter{}, errInvalidBBox
+		}
+		values[k] = v
+	}
+
+	f.BBox = &BoundingBox{
+		MinLat: values["min_lat"],
+		MaxLat: values["max_lat"],
+		MinLon: values["min_lon"],
+		MaxLon: values["max_lon"],
+	}
+	return f, nil
+}
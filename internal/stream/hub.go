@@ -0,0 +1,181 @@
+// Package stream turns the fleet service into a publish/subscribe hub:
+// every event that is successfully processed is fanned out to WebSocket
+// subscribers filtered by vehicle ID, event type, and geographic bounding
+// box.
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/saisudhir14/fleet-event-stream/internal/metrics"
+	"github.com/saisudhir14/fleet-event-stream/internal/models"
+)
+
+// defaultOutboundQueueSize bounds how many unsent messages a single
+// subscriber can accumulate before Hub starts dropping events for it.
+const defaultOutboundQueueSize = 16
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// Subscribers are expected to be internal consumers of the fleet
+	// stream; origin checking is left to an upstream proxy.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// Hub tracks connected subscribers and fans out processed events to the
+// ones whose filter matches. It satisfies processor.Publisher.
+type Hub struct {
+	mu                sync.RWMutex
+	clients           map[*client]struct{}
+	outboundQueueSize int
+	logger            *slog.Logger
+	metrics           *metrics.Metrics
+	wg                sync.WaitGroup
+}
+
+// NewHub creates a new Hub. outboundQueueSize configures the per-client
+// bounded send queue; a value <= 0 uses defaultOutboundQueueSize.
+// parameters: logger *slog.Logger: The logger instance.
+//
+//	m *metrics.Metrics: The metrics collector instance.
+//	outboundQueueSize int: The per-client outbound queue depth.
+//
+// returns: *Hub: A new Hub instance.
+func NewHub(logger *slog.Logger, m *metrics.Metrics, outboundQueueSize int) *Hub {
+	if outboundQueueSize <= 0 {
+		outboundQueueSize = defaultOutboundQueueSize
+	}
+	return &Hub{
+		clients:           make(map[*client]struct{}),
+		outboundQueueSize: outboundQueueSize,
+		logger:            logger,
+		metrics:           m,
+	}
+}
+
+// ServeWS upgrades the request to a WebSocket connection, registers it as a
+// subscriber filtered according to its query parameters (vehicle_id,
+// event_type, min_lat/max_lat/min_lon/max_lon), and starts its read/write
+// pumps.
+// parameters: w http.ResponseWriter: The HTTP response writer.
+//
+//	r *http.Request: The subscribe request.
+//
+// returns: none but writes an HTTP error if the filter is invalid or the upgrade fails.
+func (h *Hub) ServeWS(w http.ResponseWriter, r *http.Request) {
+	filter, err := ParseFilter(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.logger.Error("websocket upgrade failed", "error", err)
+		return
+	}
+
+	c := &client{
+		hub:    h,
+		conn:   conn,
+		send:   make(chan []byte, h.outboundQueueSize),
+		filter: filter,
+	}
+	h.register(c)
+
+	go c.writePump()
+	go c.readPump()
+}
+
+func (h *Hub) register(c *client) {
+	h.mu.Lock()
+	h.clients[c] = struct{}{}
+	h.mu.Unlock()
+	h.wg.Add(1)
+	h.metrics.UpdateWSConnections(float64(h.connectionCount()))
+}
+
+func (h *Hub) unregister(c *client) {
+	h.mu.Lock()
+	if _, ok := h.clients[c]; ok {
+		delete(h.clients, c)
+		close(c.send)
+	}
+	h.mu.Unlock()
+	h.metrics.UpdateWSConnections(float64(h.connectionCount()))
+}
+
+func (h *Hub) connectionCount() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.clients)
+}
+
+// Publish fans event out to every subscriber whose filter matches it. It
+// never blocks on a slow client: a client whose outbound queue is full has
+// the event dropped for it and fleet_ws_dropped_events_total incremented,
+// so a stalled subscriber can never back-pressure the ingest path.
+// parameters: event *models.VehicleEvent: The event to fan out.
+// returns: none
+func (h *Hub) Publish(event *models.VehicleEvent) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if len(h.clients) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		h.logger.Error("failed to marshal event for subscribers", "error", err)
+		return
+	}
+
+	for c := range h.clients {
+		if !c.filter.Match(event) {
+			continue
+		}
+		select {
+		case c.send <- payload:
+		default:
+			h.metrics.RecordWSDroppedEvent()
+		}
+	}
+}
+
+// Shutdown closes every connected subscriber with a normal close frame and
+// stops accepting new events. It is safe to call from the same graceful
+// shutdown path that stops the HTTP servers.
+//
+// It does not write to the connections itself: conn is owned exclusively by
+// each client's writePump (gorilla/websocket conns support only one
+// concurrent writer), so Shutdown just closes c.send the same way unregister
+// does and lets writePump send the close frame and exit on its own. Shutdown
+// waits for every writePump to finish, up to ctx's deadline.
+// parameters: ctx context.Context: Bounds how long Shutdown waits for clients to close.
+// returns: none
+func (h *Hub) Shutdown(ctx context.Context) {
+	h.mu.Lock()
+	for c := range h.clients {
+		close(c.send)
+		delete(h.clients, c)
+	}
+	h.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		h.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
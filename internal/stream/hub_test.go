@@ -0,0 +1,100 @@
+package stream
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/saisudhir14/fleet-event-stream/internal/metrics"
+	"github.com/saisudhir14/fleet-event-stream/internal/models"
+)
+
+func newTestHub(t *testing.T) *Hub {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	m := metrics.NewMetrics(prometheus.NewRegistry(), metrics.MetricsConfig{})
+	return NewHub(logger, m, 16)
+}
+
+func dialTestClient(t *testing.T, server *httptest.Server) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	return conn
+}
+
+// TestHub_ShutdownDuringConcurrentPublish is a regression test for a data
+// race fixed in Shutdown: it used to write the close frame and call
+// conn.Close() directly from the shutdown goroutine while each client's own
+// writePump goroutine could concurrently write a queued message or ping to
+// the same *websocket.Conn, which only tolerates a single writer. Run with
+// `go test -race` to catch a reintroduction of that race.
+func TestHub_ShutdownDuringConcurrentPublish(t *testing.T) {
+	hub := newTestHub(t)
+	server := httptest.NewServer(http.HandlerFunc(hub.ServeWS))
+	defer server.Close()
+
+	conn := dialTestClient(t, server)
+	defer conn.Close()
+
+	// Drain incoming frames on another goroutine so the client's own read
+	// side doesn't block publishes or the close handshake.
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	// Give ServeWS's register a moment to run before publishing.
+	deadline := time.Now().Add(time.Second)
+	for hub.connectionCount() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("client never registered")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	stop := make(chan struct{})
+	var publishers sync.WaitGroup
+	publishers.Add(1)
+	go func() {
+		defer publishers.Done()
+		event := &models.VehicleEvent{
+			EventID:   "evt-1",
+			VehicleID: "vehicle-1",
+			EventType: models.EventTypePosition,
+			Timestamp: time.Now(),
+		}
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				hub.Publish(event)
+			}
+		}
+	}()
+
+	// Let a few publishes land before racing Shutdown against them.
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	hub.Shutdown(ctx)
+
+	close(stop)
+	publishers.Wait()
+}